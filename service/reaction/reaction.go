@@ -1,6 +1,7 @@
 package reaction
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
@@ -33,17 +34,22 @@ var TypeToIdentifier = map[Type]string{
 
 // Consumer observes state changes.
 type Consumer interface {
-	Consume() (*StateChange, error)
+	// Consume blocks until a StateChange is available or ctx is canceled,
+	// in which case ctx.Err() is returned so long-running consumers don't
+	// leak goroutines when the surrounding request is canceled.
+	Consume(ctx context.Context) (*StateChange, error)
 }
 
-// Counts bundles all Reaction counts by type.
+// Counts bundles all Reaction counts by type. Custom holds counts for any
+// namespace-registered types beyond the six built-ins.
 type Counts struct {
-	Angry uint64
-	Haha  uint64
-	Like  uint64
-	Love  uint64
-	Sad   uint64
-	Wow   uint64
+	Angry  uint64
+	Haha   uint64
+	Like   uint64
+	Love   uint64
+	Sad    uint64
+	Wow    uint64
+	Custom map[Type]uint64 `json:"custom,omitempty"`
 }
 
 // CountsMap is the association of an object id to Counts.
@@ -96,7 +102,8 @@ type Producer interface {
 	Propagate(namespace string, old, new *Reaction) (string, error)
 }
 
-// QueryOptions to narrow-down queries.
+// QueryOptions to narrow-down queries. Types accepts both built-in and
+// namespace-registered custom Type values.
 type QueryOptions struct {
 	Before    time.Time `json:"-"`
 	Deleted   *bool     `json:"deleted,omitempty"`
@@ -146,7 +153,10 @@ func (r *Reaction) MatchOpts(opts *QueryOptions) bool {
 	return true
 }
 
-// Validate checks for semantic correctness.
+// Validate checks for semantic correctness against the six built-in types.
+// It does not know about namespace-registered custom types since Reaction
+// carries no namespace of its own; callers with a TypeRegistry in scope
+// should use ValidateType instead.
 func (r *Reaction) Validate() error {
 	if r.ObjectID == 0 {
 		return serr.Wrap(serr.ErrInvalidReaction, "missing object id")
@@ -163,14 +173,39 @@ func (r *Reaction) Validate() error {
 	return nil
 }
 
-// Service for Reaction interactions.
+// ValidateType checks Type against the built-in range and, additionally,
+// any custom type registered for ns in reg (a nil reg allows the built-ins
+// only). It checks Type only: unlike Validate, it does not check ObjectID or
+// OwnerID, and Validate itself rejects every custom type.
+func (r *Reaction) ValidateType(reg TypeRegistry, ns string) error {
+	if r.Type >= TypeLike && r.Type <= TypeAngry {
+		return nil
+	}
+
+	if reg != nil {
+		types, err := reg.List(ns)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := types[r.Type]; ok {
+			return nil
+		}
+	}
+
+	return serr.Wrap(serr.ErrInvalidReaction, "unspported type '%d'", r.Type)
+}
+
+// Service for Reaction interactions. All operations take a context.Context
+// as their first argument so callers can propagate request cancellation,
+// per-call deadlines, and tracing spans down into the storage backend.
 type Service interface {
 	service.Lifecycle
 
-	Count(namespace string, opts QueryOptions) (uint, error)
-	CountMulti(namespace string, opts QueryOptions) (CountsMap, error)
-	Put(namespace string, reaction *Reaction) (*Reaction, error)
-	Query(namespace string, opts QueryOptions) (List, error)
+	Count(ctx context.Context, namespace string, opts QueryOptions) (uint, error)
+	CountMulti(ctx context.Context, namespace string, opts QueryOptions) (CountsMap, error)
+	Put(ctx context.Context, namespace string, reaction *Reaction) (*Reaction, error)
+	Query(ctx context.Context, namespace string, opts QueryOptions) (List, error)
 }
 
 // ServiceMiddleware is a chainable behaviour modifier for Service.
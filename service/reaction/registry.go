@@ -0,0 +1,109 @@
+package reaction
+
+import (
+	"sync"
+
+	serr "github.com/tapglue/snaas/error"
+)
+
+// TypeRegistry manages per-namespace Reaction types, seeded with the six
+// built-ins (TypeLike..TypeAngry) and extensible at runtime so app owners
+// can declare custom reactions (e.g. "celebrate", "curious", emoji-based).
+type TypeRegistry interface {
+	// List returns all types registered for the namespace, keyed by Type.
+	List(ns string) (map[Type]string, error)
+
+	// Lookup resolves identifier to its Type within the namespace.
+	Lookup(ns, identifier string) (Type, error)
+
+	// Register adds a new custom type under identifier, returning its
+	// assigned Type. It returns a serr.ErrInvalidReaction wrapped error if
+	// identifier is already taken in the namespace.
+	Register(ns, identifier string) (Type, error)
+}
+
+type memTypeRegistry struct {
+	mu    sync.Mutex
+	ids   map[string]map[string]Type
+	next  map[string]Type
+	types map[string]map[Type]string
+}
+
+// MemTypeRegistry returns a memory based TypeRegistry implementation. Every
+// namespace is lazily seeded with the six built-in types on first access,
+// acting as the migration path for existing namespaces.
+func MemTypeRegistry() TypeRegistry {
+	return &memTypeRegistry{
+		ids:   map[string]map[string]Type{},
+		next:  map[string]Type{},
+		types: map[string]map[Type]string{},
+	}
+}
+
+func (r *memTypeRegistry) List(ns string) (map[Type]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seed(ns)
+
+	ts := make(map[Type]string, len(r.types[ns]))
+
+	for t, identifier := range r.types[ns] {
+		ts[t] = identifier
+	}
+
+	return ts, nil
+}
+
+func (r *memTypeRegistry) Lookup(ns, identifier string) (Type, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seed(ns)
+
+	t, ok := r.ids[ns][identifier]
+	if !ok {
+		return 0, serr.Wrap(serr.ErrInvalidReaction, "type '%s' not found", identifier)
+	}
+
+	return t, nil
+}
+
+func (r *memTypeRegistry) Register(ns, identifier string) (Type, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seed(ns)
+
+	if _, ok := r.ids[ns][identifier]; ok {
+		return 0, serr.Wrap(serr.ErrInvalidReaction, "type '%s' already registered", identifier)
+	}
+
+	r.next[ns]++
+	t := r.next[ns]
+
+	r.types[ns][t] = identifier
+	r.ids[ns][identifier] = t
+
+	return t, nil
+}
+
+// seed populates ns with the six built-in types on first access. Callers
+// must hold r.mu.
+func (r *memTypeRegistry) seed(ns string) {
+	if _, ok := r.types[ns]; ok {
+		return
+	}
+
+	r.ids[ns] = map[string]Type{}
+	r.types[ns] = map[Type]string{}
+
+	for t, identifier := range TypeToIdentifier {
+		r.ids[ns][identifier] = t
+		r.types[ns][t] = identifier
+
+		if t > r.next[ns] {
+			r.next[ns] = t
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package reaction
+
+import "testing"
+
+func TestMemTypeRegistrySeedsBuiltins(t *testing.T) {
+	reg := MemTypeRegistry()
+
+	ts, err := reg.List("ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for ty, identifier := range TypeToIdentifier {
+		if got := ts[ty]; got != identifier {
+			t.Errorf("List()[%d] = %q, want %q", ty, got, identifier)
+		}
+	}
+}
+
+func TestMemTypeRegistryRegisterLookup(t *testing.T) {
+	reg := MemTypeRegistry()
+
+	ty, err := reg.Register("ns", "celebrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ty <= TypeAngry {
+		t.Errorf("Register() = %d, want a Type beyond the built-ins (> %d)", ty, TypeAngry)
+	}
+
+	got, err := reg.Lookup("ns", "celebrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != ty {
+		t.Errorf("Lookup() = %d, want %d", got, ty)
+	}
+}
+
+func TestMemTypeRegistryRegisterDuplicate(t *testing.T) {
+	reg := MemTypeRegistry()
+
+	if _, err := reg.Register("ns", "celebrate"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reg.Register("ns", "celebrate"); err == nil {
+		t.Error("Register() with a taken identifier should return an error")
+	}
+}
+
+func TestMemTypeRegistryLookupNotFound(t *testing.T) {
+	reg := MemTypeRegistry()
+
+	if _, err := reg.Lookup("ns", "does-not-exist"); err == nil {
+		t.Error("Lookup() for an unregistered identifier should return an error")
+	}
+}
+
+func TestMemTypeRegistryNamespacesIsolated(t *testing.T) {
+	reg := MemTypeRegistry()
+
+	if _, err := reg.Register("ns-a", "celebrate"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reg.Lookup("ns-b", "celebrate"); err == nil {
+		t.Error("a type registered for ns-a should not be visible in ns-b")
+	}
+}
@@ -0,0 +1,342 @@
+package user
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/arbovm/levenshtein"
+)
+
+// Indexer builds and maintains an inverted index over User fields so that
+// Search ranks a small set of candidates instead of scanning every User on
+// every request. The same interface is shared by the postgres-backed
+// Service, materialized there with pg_trgm.
+type Indexer interface {
+	// Index (re-)indexes u under ns, replacing any previous entry for its
+	// id.
+	Index(ns string, u *User) error
+
+	// Remove drops id from the index for ns.
+	Remove(ns string, id uint64) error
+
+	// Teardown discards the whole index for ns.
+	Teardown(ns string) error
+
+	// Candidates returns ids ranked by relevance to query, most relevant
+	// first. Matches scoring below minScore are discarded.
+	Candidates(ns, query string) ([]uint64, error)
+}
+
+// minScore is the minimum combined score a candidate must reach to be
+// considered a match, keeping weak single-trigram overlaps out of results.
+const minScore = 0.2
+
+// exactScore is the full score bonus for a candidate that has an exact
+// posting-list hit on every token of the query; candidates matching only
+// some of the query's tokens get a proportionally scaled share of it (see
+// Candidates). At full strength it must clear minScore on its own so that
+// an exact match on one field (e.g. firstname "Jo") can't be diluted away
+// by trigram noise from an unrelated, much longer field (e.g. a long email
+// or username) on the same doc.
+const exactScore = 1
+
+// doc is the indexed representation of a single User. fieldTrigrams holds
+// one trigram set per non-empty field so a query is scored against each
+// field independently (best field wins) instead of against one trigram bag
+// pooled across all fields, which a long field would otherwise dominate.
+type doc struct {
+	primary       string
+	allTokens     map[string]struct{}
+	allTrigrams   map[string]struct{}
+	fieldTrigrams []map[string]struct{}
+}
+
+type memIndexer struct {
+	mu             sync.Mutex
+	docs           map[string]map[uint64]doc
+	tokenPosting   map[string]map[string]map[uint64]struct{}
+	trigramPosting map[string]map[string]map[uint64]struct{}
+}
+
+// MemIndexer returns a memory based Indexer implementation backing
+// memService.Search.
+func MemIndexer() Indexer {
+	return &memIndexer{
+		docs:           map[string]map[uint64]doc{},
+		tokenPosting:   map[string]map[string]map[uint64]struct{}{},
+		trigramPosting: map[string]map[string]map[uint64]struct{}{},
+	}
+}
+
+func (idx *memIndexer) Index(ns string, u *User) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.ensure(ns)
+	idx.removeLocked(ns, u.ID)
+
+	d := doc{
+		primary:     normalize(u.Username),
+		allTokens:   map[string]struct{}{},
+		allTrigrams: map[string]struct{}{},
+	}
+
+	for _, field := range []string{u.Username, u.Email, u.Firstname, u.Lastname} {
+		tokens := tokenize(field)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		ft := map[string]struct{}{}
+
+		for _, token := range tokens {
+			d.allTokens[token] = struct{}{}
+
+			for tri := range trigramSet(token) {
+				ft[tri] = struct{}{}
+				d.allTrigrams[tri] = struct{}{}
+			}
+		}
+
+		d.fieldTrigrams = append(d.fieldTrigrams, ft)
+	}
+
+	idx.docs[ns][u.ID] = d
+
+	for token := range d.allTokens {
+		addPosting(idx.tokenPosting[ns], token, u.ID)
+	}
+
+	for tri := range d.allTrigrams {
+		addPosting(idx.trigramPosting[ns], tri, u.ID)
+	}
+
+	return nil
+}
+
+func (idx *memIndexer) Remove(ns string, id uint64) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.ensure(ns)
+	idx.removeLocked(ns, id)
+
+	return nil
+}
+
+func (idx *memIndexer) Teardown(ns string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	delete(idx.docs, ns)
+	delete(idx.tokenPosting, ns)
+	delete(idx.trigramPosting, ns)
+
+	return nil
+}
+
+func (idx *memIndexer) Candidates(ns, query string) ([]uint64, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.ensure(ns)
+
+	var (
+		qNorm     = normalize(query)
+		qTokens   = tokenize(qNorm)
+		qTrigrams = map[string]struct{}{}
+	)
+
+	for _, t := range qTokens {
+		for tri := range trigramSet(t) {
+			qTrigrams[tri] = struct{}{}
+		}
+	}
+
+	// matched counts, per id, how many qTokens (a repeated query token counts
+	// each time) it has an exact posting-list hit on so the exact-hit bonus below can be scaled by the
+	// fraction of the query actually matched, rather than handed out in
+	// full for a single-token hit on a multi-word query.
+	matched := map[uint64]int{}
+
+	for _, t := range qTokens {
+		for id := range idx.tokenPosting[ns][t] {
+			matched[id]++
+		}
+	}
+
+	seen := map[uint64]struct{}{}
+
+	for id := range matched {
+		seen[id] = struct{}{}
+	}
+
+	for tri := range qTrigrams {
+		for id := range idx.trigramPosting[ns][tri] {
+			seen[id] = struct{}{}
+		}
+	}
+
+	type scored struct {
+		id    uint64
+		score float64
+	}
+
+	rs := make([]scored, 0, len(seen))
+
+	for id := range seen {
+		d, ok := idx.docs[ns][id]
+		if !ok {
+			continue
+		}
+
+		// Score each field's trigrams against the query independently and
+		// keep the best match, so a hit on a short field (e.g. firstname)
+		// isn't diluted by an unrelated, much longer field (e.g. email) on
+		// the same doc.
+		fieldScore := 0.0
+
+		for _, ft := range d.fieldTrigrams {
+			if s := jaccard(qTrigrams, ft); s > fieldScore {
+				fieldScore = s
+			}
+		}
+
+		score := fieldScore
+
+		if d.primary != "" && strings.HasPrefix(d.primary, qNorm) {
+			score += 0.5
+		}
+
+		// Truncated Levenshtein tiebreaker: contributes a small, bounded
+		// nudge so near-identical usernames outrank trigram-equal ones
+		// without dominating the trigram/prefix signal.
+		dist := levenshtein.Distance(qNorm, d.primary)
+		if dist > 8 {
+			dist = 8
+		}
+		score += 0.1 * (1 - float64(dist)/8)
+
+		if count := matched[id]; count > 0 && len(qTokens) > 0 {
+			score += exactScore * float64(count) / float64(len(qTokens))
+		}
+
+		if score < minScore {
+			continue
+		}
+
+		rs = append(rs, scored{id: id, score: score})
+	}
+
+	sort.SliceStable(rs, func(i, j int) bool {
+		if rs[i].score != rs[j].score {
+			return rs[i].score > rs[j].score
+		}
+
+		return rs[i].id < rs[j].id
+	})
+
+	ids := make([]uint64, len(rs))
+	for i, r := range rs {
+		ids[i] = r.id
+	}
+
+	return ids, nil
+}
+
+// ensure initializes the per-namespace maps on first use. Callers must hold
+// idx.mu.
+func (idx *memIndexer) ensure(ns string) {
+	if _, ok := idx.docs[ns]; ok {
+		return
+	}
+
+	idx.docs[ns] = map[uint64]doc{}
+	idx.tokenPosting[ns] = map[string]map[uint64]struct{}{}
+	idx.trigramPosting[ns] = map[string]map[uint64]struct{}{}
+}
+
+// removeLocked drops id from ns, if present. Callers must hold idx.mu.
+func (idx *memIndexer) removeLocked(ns string, id uint64) {
+	d, ok := idx.docs[ns][id]
+	if !ok {
+		return
+	}
+
+	for token := range d.allTokens {
+		removePosting(idx.tokenPosting[ns], token, id)
+	}
+
+	for tri := range d.allTrigrams {
+		removePosting(idx.trigramPosting[ns], tri, id)
+	}
+
+	delete(idx.docs[ns], id)
+}
+
+func addPosting(m map[string]map[uint64]struct{}, key string, id uint64) {
+	if _, ok := m[key]; !ok {
+		m[key] = map[uint64]struct{}{}
+	}
+
+	m[key][id] = struct{}{}
+}
+
+func removePosting(m map[string]map[uint64]struct{}, key string, id uint64) {
+	ids, ok := m[key]
+	if !ok {
+		return
+	}
+
+	delete(ids, id)
+
+	if len(ids) == 0 {
+		delete(m, key)
+	}
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	inter := 0
+
+	for k := range a {
+		if _, ok := b[k]; ok {
+			inter++
+		}
+	}
+
+	union := len(a) + len(b) - inter
+
+	return float64(inter) / float64(union)
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// tokenize lowercase-normalizes s and splits it on non-alphanumeric
+// boundaries.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(normalize(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// trigramSet returns the set of character trigrams of s, padded with a
+// leading/trailing space so short tokens still produce boundary trigrams.
+func trigramSet(s string) map[string]struct{} {
+	set := map[string]struct{}{}
+
+	padded := []rune(" " + s + " ")
+
+	for i := 0; i+3 <= len(padded); i++ {
+		set[string(padded[i:i+3])] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,120 @@
+package user
+
+import "testing"
+
+func TestMemIndexerExactHitSurvivesLongFieldDilution(t *testing.T) {
+	idx := MemIndexer()
+
+	u := &User{
+		ID:        1,
+		Username:  "xk92_enterprise_platform_accountholder",
+		Email:     "contact@biglongcorporatedomainname.example.org",
+		Firstname: "Jo",
+	}
+
+	if err := idx.Index("ns", u); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := idx.Candidates("ns", "jo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 1 || ids[0] != u.ID {
+		t.Fatalf("Candidates() = %v, want [%d]", ids, u.ID)
+	}
+}
+
+func TestMemIndexerFuzzyCandidatesRankedByRelevance(t *testing.T) {
+	idx := MemIndexer()
+
+	close := &User{ID: 1, Username: "alice"}
+	far := &User{ID: 2, Username: "zzzzzzz"}
+
+	for _, u := range []*User{close, far} {
+		if err := idx.Index("ns", u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids, err := idx.Candidates("ns", "alic")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) == 0 || ids[0] != close.ID {
+		t.Fatalf("Candidates() = %v, want %d ranked first", ids, close.ID)
+	}
+}
+
+func TestMemIndexerRemove(t *testing.T) {
+	idx := MemIndexer()
+
+	u := &User{ID: 1, Username: "alice"}
+
+	if err := idx.Index("ns", u); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := idx.Remove("ns", u.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := idx.Candidates("ns", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 0 {
+		t.Fatalf("Candidates() = %v, want none after Remove", ids)
+	}
+}
+
+func TestMemIndexerExactBonusScaledByMatchedTokenFraction(t *testing.T) {
+	idx := MemIndexer()
+
+	// janeSmith has the lowest id, so with a flat exact bonus it would tie
+	// janeDoe and win the id tie-break.
+	janeDoe := &User{ID: 3, Firstname: "Jane", Lastname: "Doe"}
+	doeJohnson := &User{ID: 2, Firstname: "Doe", Lastname: "Johnson"}
+	janeSmith := &User{ID: 1, Firstname: "Jane", Lastname: "Smith"}
+
+	for _, u := range []*User{janeDoe, doeJohnson, janeSmith} {
+		if err := idx.Index("ns", u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids, err := idx.Candidates("ns", "jane doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 3 || ids[0] != janeDoe.ID {
+		t.Fatalf("Candidates() = %v, want the full match %d ranked first", ids, janeDoe.ID)
+	}
+
+	if ids[1] != janeSmith.ID || ids[2] != doeJohnson.ID {
+		t.Fatalf("Candidates() = %v, want single-token matches ranked by fieldScore", ids)
+	}
+}
+
+func TestMemIndexerNoWeakMatches(t *testing.T) {
+	idx := MemIndexer()
+
+	u := &User{ID: 1, Username: "bjorn-enterprise-customer-relations-team"}
+
+	if err := idx.Index("ns", u); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := idx.Candidates("ns", "jo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 0 {
+		t.Fatalf("Candidates() = %v, want none for a weak substring-only overlap", ids)
+	}
+}
@@ -1,28 +1,32 @@
 package user
 
 import (
-	"sort"
+	"context"
 	"strings"
 	"time"
 
-	"github.com/arbovm/levenshtein"
-
 	serr "github.com/tapglue/snaas/error"
 	"github.com/tapglue/snaas/platform/flake"
 )
 
 type memService struct {
+	index Indexer
 	users map[string]Map
 }
 
 // MemService returns a memory based Service implementation.
 func MemService() Service {
 	return &memService{
+		index: MemIndexer(),
 		users: map[string]Map{},
 	}
 }
 
-func (s *memService) Count(ns string, opts QueryOptions) (int, error) {
+func (s *memService) Count(ctx context.Context, ns string, opts QueryOptions) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	if err := s.Setup(ns); err != nil {
 		return 0, err
 	}
@@ -30,7 +34,11 @@ func (s *memService) Count(ns string, opts QueryOptions) (int, error) {
 	return len(filterList(s.users[ns].ToList(), opts)), nil
 }
 
-func (s *memService) Put(ns string, input *User) (*User, error) {
+func (s *memService) Put(ctx context.Context, ns string, input *User) (*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := s.Setup(ns); err != nil {
 		return nil, err
 	}
@@ -72,10 +80,18 @@ func (s *memService) Put(ns string, input *User) (*User, error) {
 	input.UpdatedAt = now
 	bucket[input.ID] = copy(input)
 
+	if err := s.index.Index(ns, input); err != nil {
+		return nil, err
+	}
+
 	return copy(input), nil
 }
 
-func (s *memService) PutLastRead(ns string, userID uint64, ts time.Time) error {
+func (s *memService) PutLastRead(ctx context.Context, ns string, userID uint64, ts time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if err := s.Setup(ns); err != nil {
 		return err
 	}
@@ -88,7 +104,11 @@ func (s *memService) PutLastRead(ns string, userID uint64, ts time.Time) error {
 
 	return nil
 }
-func (s *memService) Query(ns string, opts QueryOptions) (List, error) {
+func (s *memService) Query(ctx context.Context, ns string, opts QueryOptions) (List, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := s.Setup(ns); err != nil {
 		return nil, err
 	}
@@ -102,7 +122,11 @@ func (s *memService) Query(ns string, opts QueryOptions) (List, error) {
 	return us, nil
 }
 
-func (s *memService) Search(ns string, opts QueryOptions) (List, error) {
+func (s *memService) Search(ctx context.Context, ns string, opts QueryOptions) (List, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := s.Setup(ns); err != nil {
 		return nil, err
 	}
@@ -111,29 +135,30 @@ func (s *memService) Search(ns string, opts QueryOptions) (List, error) {
 		return nil, serr.Wrap(serr.ErrInvalidQuery, "param is empty")
 	}
 
-	us := s.users[ns].ToList()
-
-	sort.SliceStable(us, func(i, j int) bool {
-		return levenshtein.Distance(opts.Query, us[i].Username) < levenshtein.Distance(opts.Query, us[j].Username)
-	})
+	ids, err := s.index.Candidates(ns, opts.Query)
+	if err != nil {
+		return nil, err
+	}
 
-	fs := List{}
+	us := List{}
 
-	for _, u := range us {
-		if levenshtein.Distance(opts.Query, u.Username) < 8 {
-			fs = append(fs, u)
+	for _, id := range ids {
+		if u, ok := s.users[ns][id]; ok {
+			us = append(us, u)
 		}
 	}
 
-	if int(opts.Offset) > len(us) {
+	if int(opts.Offset) >= len(us) {
 		return List{}, nil
 	}
 
-	if opts.Limit == 0 && opts.Offset == 0 {
-		return us, nil
+	us = us[int(opts.Offset):]
+
+	if opts.Limit > 0 && len(us) > opts.Limit {
+		us = us[:opts.Limit]
 	}
 
-	return us[int(opts.Offset) : int(opts.Offset)+opts.Limit], nil
+	return us, nil
 }
 
 func (s *memService) Setup(ns string) error {
@@ -149,7 +174,7 @@ func (s *memService) Teardown(ns string) error {
 		delete(s.users, ns)
 	}
 
-	return nil
+	return s.index.Teardown(ns)
 }
 
 func contains(s string, ts ...string) bool {
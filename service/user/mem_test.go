@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemServiceCanceledContext(t *testing.T) {
+	s := MemService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.Count(ctx, "ns", QueryOptions{}); err != context.Canceled {
+		t.Errorf("Count() err = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := s.Put(ctx, "ns", &User{Username: "alice", Email: "alice@example.org", CustomID: "alice"}); err != context.Canceled {
+		t.Errorf("Put() err = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := s.Query(ctx, "ns", QueryOptions{}); err != context.Canceled {
+		t.Errorf("Query() err = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := s.Search(ctx, "ns", QueryOptions{Query: "alice"}); err != context.Canceled {
+		t.Errorf("Search() err = %v, want %v", err, context.Canceled)
+	}
+
+	if err := s.PutLastRead(ctx, "ns", 1, time.Now()); err != context.Canceled {
+		t.Errorf("PutLastRead() err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestMemServicePutThenSearch(t *testing.T) {
+	s := MemService()
+	ctx := context.Background()
+
+	if _, err := s.Put(ctx, "ns", &User{Username: "alice", Email: "alice@example.org", CustomID: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	us, err := s.Search(ctx, "ns", QueryOptions{Query: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(us) != 1 || us[0].Username != "alice" {
+		t.Fatalf("Search() = %v, want a single match for alice", us)
+	}
+}